@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package as the source of the spans it
+// creates, per the OTel convention of naming a tracer after the library that
+// owns it rather than the service using it.
+const instrumentationName = "github.com/AkhilManoj03/practice-devops/recommendation/telemetry"
+
+// requestIDBaggageKey is the baggage member carrying the inbound request ID
+// to downstream spans created from the request's context.
+const requestIDBaggageKey = "request.id"
+
+// Tracer returns the tracer this package uses for its own spans, sourced from
+// whatever TracerProvider InitTracer (or InitTracerWithConfig) installed.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartSpan starts a child span named name under ctx's current span, with the
+// given attributes attached up front.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Middleware wraps next with an otelhttp handler that creates a server span
+// per request named after routePattern (e.g. "/recommendations/{id}"), and
+// adds the inbound X-Request-Id header as a baggage member on the context
+// next receives, so it travels with that context to downstream calls. The
+// member is not recorded as a span attribute.
+//
+// The request-id member is added to next's request, not the outer handler's:
+// otelhttp.NewHandler runs the configured propagator's Extract before calling
+// next, so any baggage the caller sent arrives on the context first and we
+// merge into it instead of it overwriting our member.
+func Middleware(routePattern string, next http.Handler) http.Handler {
+	withRequestID := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reqID := r.Header.Get("X-Request-Id"); reqID != "" {
+			if member, err := baggage.NewMember(requestIDBaggageKey, reqID); err == nil {
+				if bag, err := baggage.FromContext(r.Context()).SetMember(member); err == nil {
+					r = r.WithContext(baggage.ContextWithBaggage(r.Context(), bag))
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+
+	return otelhttp.NewHandler(withRequestID, routePattern,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + routePattern
+		}),
+	)
+}
+
+// NewTransport wraps base (http.DefaultTransport if nil) so outbound requests
+// get client spans and carry traceparent/baggage headers via the propagator
+// InitTracer installed with otel.SetTextMapPropagator.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return otelhttp.NewTransport(base)
+}
@@ -2,28 +2,275 @@ package telemetry
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/contrib/samplers/jaegerremote"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
 )
 
+// otlpProtocol identifies which wire protocol the OTLP exporter should speak,
+// matching the values accepted by OTEL_EXPORTER_OTLP_PROTOCOL.
+type otlpProtocol string
+
+const (
+	protocolGRPC          otlpProtocol = "grpc"
+	protocolHTTPProtobuf  otlpProtocol = "http/protobuf"
+	defaultOTLPProtocol                = protocolHTTPProtobuf
+)
+
+// TracerConfig controls how InitTracer builds the trace pipeline. Use
+// TracerConfigFromEnv to populate it the same way InitTracer does by default.
+type TracerConfig struct {
+	ServiceName string
+	Endpoint    otlpEndpoint
+	// Exporter selects the span exporter: "otlp" (default), "console", or "none".
+	Exporter string
+	// Disabled mirrors OTEL_SDK_DISABLED: when true, tracing is a no-op.
+	Disabled bool
+}
+
+// TracerConfigFromEnv builds a TracerConfig from the standard OTel env vars.
+func TracerConfigFromEnv() TracerConfig {
+	exporter := os.Getenv("OTEL_TRACES_EXPORTER")
+	if exporter == "" {
+		exporter = "otlp"
+	}
+
+	return TracerConfig{
+		ServiceName: resolveServiceName(),
+		Endpoint:    resolveTracesEndpoint(),
+		Exporter:    exporter,
+		Disabled:    strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true"),
+	}
+}
+
 func InitTracer() (func(context.Context) error, error) {
+	return InitTracerWithConfig(TracerConfigFromEnv())
+}
+
+// InitTracerWithConfig builds the trace pipeline described by cfg. Callers
+// that just want the env-driven behavior should use InitTracer instead.
+func InitTracerWithConfig(cfg TracerConfig) (func(context.Context) error, error) {
 	ctx := context.Background()
 
+	if cfg.Disabled {
+		otel.SetTracerProvider(tracenoop.NewTracerProvider())
+		return noopShutdown, nil
+	}
+
+	res, err := newResource(cfg.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("creating resource: %w", err)
+	}
+
+	exporter, err := newSpanExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s trace exporter: %w", cfg.Exporter, err)
+	}
+	if exporter == nil {
+		// OTEL_TRACES_EXPORTER=none: run without ever exporting spans.
+		otel.SetTracerProvider(tracenoop.NewTracerProvider())
+		return noopShutdown, nil
+	}
+
+	sampler, err := newSampler(cfg.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("configuring sampler: %w", err)
+	}
+
+	// Configure trace provider with batch processor
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter,
+			sdktrace.WithBatchTimeout(time.Second),
+		),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler),
+	)
+
+	// Set global trace provider and propagator
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	// Return shutdown function
+	return tracerProvider.Shutdown, nil
+}
+
+func noopShutdown(context.Context) error { return nil }
+
+// newSpanExporter selects a span exporter by cfg.Exporter, following the same
+// provider-name selection pattern as
+// go.opentelemetry.io/contrib/exporters/autoexport. A Jaeger collector is
+// reached through the "otlp" exporter too, since Jaeger speaks OTLP natively.
+// A nil exporter with a nil error means "none": the caller should skip
+// exporting entirely rather than start a batcher with nowhere to send spans.
+func newSpanExporter(ctx context.Context, cfg TracerConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case "otlp", "":
+		return newTraceExporter(ctx, cfg.Endpoint, cfg.ServiceName)
+	case "console":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q", cfg.Exporter)
+	}
+}
+
+// MeterConfig controls how InitMeter builds the metric pipeline. Use
+// MeterConfigFromEnv to populate it the same way InitMeter does by default.
+type MeterConfig struct {
+	ServiceName string
+	Endpoint    otlpEndpoint
+	// Exporter selects the metric exporter: "otlp" (default), "console", or "none".
+	Exporter string
+	// Disabled mirrors OTEL_SDK_DISABLED: when true, metrics are a no-op.
+	Disabled bool
+}
+
+// MeterConfigFromEnv builds a MeterConfig from the standard OTel env vars.
+func MeterConfigFromEnv() MeterConfig {
+	exporter := os.Getenv("OTEL_METRICS_EXPORTER")
+	if exporter == "" {
+		exporter = "otlp"
+	}
+
+	return MeterConfig{
+		ServiceName: resolveServiceName(),
+		Endpoint:    resolveMetricsEndpoint(),
+		Exporter:    exporter,
+		Disabled:    strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true"),
+	}
+}
+
+// InitMeter sets up the OTLP metric pipeline: a periodic reader pushing to the
+// same collector endpoint the tracer uses, plus Go runtime/process metrics via
+// the contrib runtime instrumentation.
+func InitMeter() (func(context.Context) error, error) {
+	return InitMeterWithConfig(MeterConfigFromEnv())
+}
+
+// InitMeterWithConfig builds the metric pipeline described by cfg. Callers
+// that just want the env-driven behavior should use InitMeter instead.
+func InitMeterWithConfig(cfg MeterConfig) (func(context.Context) error, error) {
+	ctx := context.Background()
+
+	if cfg.Disabled {
+		otel.SetMeterProvider(metricnoop.NewMeterProvider())
+		return noopShutdown, nil
+	}
+
+	res, err := newResource(cfg.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("creating resource: %w", err)
+	}
+
+	exporter, err := newMetricExporterBySelector(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s metric exporter: %w", cfg.Exporter, err)
+	}
+	if exporter == nil {
+		// OTEL_METRICS_EXPORTER=none: don't even start the runtime instrumentation.
+		otel.SetMeterProvider(metricnoop.NewMeterProvider())
+		return noopShutdown, nil
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+		sdkmetric.WithResource(res),
+	)
+
+	otel.SetMeterProvider(meterProvider)
+
+	if err := runtime.Start(runtime.WithMeterProvider(meterProvider)); err != nil {
+		return nil, fmt.Errorf("starting Go runtime metrics: %w", err)
+	}
+
+	return meterProvider.Shutdown, nil
+}
+
+// newMetricExporterBySelector selects a metric exporter by cfg.Exporter,
+// mirroring newSpanExporter's OTEL_TRACES_EXPORTER handling for metrics. A
+// nil exporter with a nil error means "none": the caller should skip the
+// reader and runtime instrumentation entirely.
+func newMetricExporterBySelector(ctx context.Context, cfg MeterConfig) (sdkmetric.Exporter, error) {
+	switch cfg.Exporter {
+	case "otlp", "":
+		return newMetricExporter(ctx, cfg.Endpoint)
+	case "console":
+		return stdoutmetric.New()
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_METRICS_EXPORTER %q", cfg.Exporter)
+	}
+}
+
+// InitTelemetry bootstraps both the tracer and meter pipelines and returns a
+// single shutdown func that flushes both providers, in the order they were
+// created.
+func InitTelemetry() (func(context.Context) error, error) {
+	shutdownTracer, err := InitTracer()
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownMeter, err := InitMeter()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		var tracerErr, meterErr error
+		if err := shutdownTracer(ctx); err != nil {
+			tracerErr = fmt.Errorf("shutting down tracer provider: %w", err)
+		}
+		if err := shutdownMeter(ctx); err != nil {
+			meterErr = fmt.Errorf("shutting down meter provider: %w", err)
+		}
+		return errors.Join(tracerErr, meterErr)
+	}, nil
+}
+
+// resolveServiceName returns OTEL_SERVICE_NAME, defaulting to the
+// recommendation service's well-known name.
+func resolveServiceName() string {
 	serviceName := os.Getenv("OTEL_SERVICE_NAME")
 	if serviceName == "" {
 		serviceName = "recommendation-service"
 	}
+	return serviceName
+}
 
+// newResource builds the shared resource.Resource describing this service,
+// used by both the tracer and meter providers.
+func newResource(serviceName string) (*resource.Resource, error) {
 	serviceVersion := os.Getenv("APP_VERSION")
 	if serviceVersion == "" {
 		serviceVersion = "1.0.0"
@@ -34,8 +281,7 @@ func InitTracer() (func(context.Context) error, error) {
 		environment = "production"
 	}
 
-	// Create resource with service information
-	res, err := resource.Merge(
+	return resource.Merge(
 		resource.Default(),
 		resource.NewWithAttributes(
 			semconv.SchemaURL,
@@ -44,58 +290,358 @@ func InitTracer() (func(context.Context) error, error) {
 			semconv.DeploymentEnvironment(environment),
 		),
 	)
-	if err != nil {
-		return nil, fmt.Errorf("creating resource: %w", err)
+}
+
+// otlpEndpoint is a parsed OTLP endpoint: the bare host:port gRPC/HTTP
+// clients connect to, the URL path to send signals on, and whether the
+// connection should skip TLS.
+type otlpEndpoint struct {
+	host     string
+	path     string
+	insecure bool
+}
+
+// resolveTracesEndpoint implements the OTLP endpoint precedence for traces:
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is a per-signal URL used verbatim
+// (path included), while OTEL_EXPORTER_OTLP_ENDPOINT is a base URL that gets
+// "/v1/traces" appended. An https:// scheme on either toggles TLS on unless
+// OTEL_EXPORTER_OTLP_INSECURE says otherwise.
+func resolveTracesEndpoint() otlpEndpoint {
+	if raw := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); raw != "" {
+		return parseOTLPEndpoint(raw, "")
 	}
 
-	// Parse OTLP endpoint from environment or use default
-	// We need just the host:port part, not the full URL
-	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
-	if endpoint == "" {
-		endpoint = "otel-collector:4318"
+	raw := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if raw == "" {
+		return otlpEndpoint{host: "otel-collector:4318", path: "/v1/traces", insecure: true}
+	}
+	return parseOTLPEndpoint(raw, "/v1/traces")
+}
+
+// resolveMetricsEndpoint mirrors resolveTracesEndpoint for the metrics signal:
+// OTEL_EXPORTER_OTLP_METRICS_ENDPOINT is used verbatim, otherwise the base
+// OTEL_EXPORTER_OTLP_ENDPOINT gets "/v1/metrics" appended. This keeps the
+// metrics pipeline on the same host and TLS defaults as the tracer instead of
+// quietly requiring TLS against the same plaintext collector.
+func resolveMetricsEndpoint() otlpEndpoint {
+	if raw := os.Getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"); raw != "" {
+		return parseOTLPEndpoint(raw, "")
+	}
+
+	raw := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if raw == "" {
+		return otlpEndpoint{host: "otel-collector:4318", path: "/v1/metrics", insecure: true}
+	}
+	return parseOTLPEndpoint(raw, "/v1/metrics")
+}
+
+// parseOTLPEndpoint splits raw into host:port and path, appending signalPath
+// to raw's own path (so a base URL such as "http://host:4318/prefix" yields
+// "/prefix/v1/traces"); with an empty signalPath raw's path is used verbatim.
+// An explicit https:// scheme disables insecure mode;
+// OTEL_EXPORTER_OTLP_INSECURE always overrides it.
+func parseOTLPEndpoint(raw, signalPath string) otlpEndpoint {
+	ep := otlpEndpoint{insecure: true}
+
+	rest := raw
+	if strings.HasPrefix(rest, "https://") {
+		rest = strings.TrimPrefix(rest, "https://")
+		ep.insecure = false
+	} else if strings.HasPrefix(rest, "http://") {
+		rest = strings.TrimPrefix(rest, "http://")
+	}
+
+	if idx := strings.Index(rest, "/"); idx != -1 {
+		ep.host, ep.path = rest[:idx], rest[idx:]
 	} else {
-		// If endpoint contains a full URL, extract just the host:port
-		if strings.HasPrefix(endpoint, "http://") {
-			endpoint = strings.TrimPrefix(endpoint, "http://")
-		} else if strings.HasPrefix(endpoint, "https://") {
-			endpoint = strings.TrimPrefix(endpoint, "https://")
-		}
-		// Remove any path portion
-		if idx := strings.Index(endpoint, "/"); idx != -1 {
-			endpoint = endpoint[:idx]
-		}
+		ep.host = rest
 	}
+	if signalPath != "" {
+		ep.path = strings.TrimSuffix(ep.path, "/") + signalPath
+	}
+
+	ep.insecure = resolveInsecure(ep.insecure)
+	return ep
+}
+
+// newTraceExporter builds the OTLP trace exporter for the protocol selected via
+// OTEL_EXPORTER_OTLP_TRACES_PROTOCOL (falling back to OTEL_EXPORTER_OTLP_PROTOCOL),
+// so the trace provider setup above stays identical regardless of transport.
+func newTraceExporter(ctx context.Context, ep otlpEndpoint, serviceName string) (*otlptrace.Exporter, error) {
+	tlsConfig, err := loadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var client otlptrace.Client
+	switch tracesProtocol() {
+	case protocolGRPC:
+		client = newGRPCClient(ep, serviceName, tlsConfig)
+	case protocolHTTPProtobuf:
+		client = newHTTPClient(ep, serviceName, tlsConfig)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", tracesProtocol())
+	}
+	return otlptrace.New(ctx, client)
+}
 
-	// Configure OTLP exporter with explicit protocol version
-	client := otlptracehttp.NewClient(
-		otlptracehttp.WithEndpoint(endpoint),
-		otlptracehttp.WithURLPath("/v1/traces"),
-		otlptracehttp.WithInsecure(),
+func tracesProtocol() otlpProtocol {
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"); p != "" {
+		return otlpProtocol(p)
+	}
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); p != "" {
+		return otlpProtocol(p)
+	}
+	return defaultOTLPProtocol
+}
+
+func newHTTPClient(ep otlpEndpoint, serviceName string, tlsConfig *tls.Config) otlptrace.Client {
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(ep.host),
+		otlptracehttp.WithURLPath(ep.path),
 		otlptracehttp.WithHeaders(map[string]string{
 			"service-name": serviceName,
 		}),
-	)
-	exporter, err := otlptrace.New(ctx, client)
+	}
+
+	if ep.insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	} else if tlsConfig != nil {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsConfig))
+	}
+
+	if isCompressionEnabled() {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+
+	return otlptracehttp.NewClient(opts...)
+}
+
+func newGRPCClient(ep otlpEndpoint, serviceName string, tlsConfig *tls.Config) otlptrace.Client {
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(ep.host),
+		otlptracegrpc.WithHeaders(map[string]string{
+			"service-name": serviceName,
+		}),
+	}
+
+	if ep.insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else if tlsConfig != nil {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	if isCompressionEnabled() {
+		opts = append(opts, otlptracegrpc.WithCompressor(gzip.Name))
+	}
+
+	return otlptracegrpc.NewClient(opts...)
+}
+
+// newMetricExporter builds the OTLP metric exporter for the protocol selected
+// via OTEL_EXPORTER_OTLP_METRICS_PROTOCOL (falling back to
+// OTEL_EXPORTER_OTLP_PROTOCOL), mirroring newTraceExporter.
+func newMetricExporter(ctx context.Context, ep otlpEndpoint) (sdkmetric.Exporter, error) {
+	tlsConfig, err := loadTLSConfig()
 	if err != nil {
-		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+		return nil, err
 	}
 
-	// Configure trace provider with batch processor
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter,
-			sdktrace.WithBatchTimeout(time.Second),
-		),
-		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	switch metricsProtocol() {
+	case protocolGRPC:
+		return newGRPCMetricExporter(ctx, ep, tlsConfig)
+	case protocolHTTPProtobuf:
+		return newHTTPMetricExporter(ctx, ep, tlsConfig)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", metricsProtocol())
+	}
+}
+
+func metricsProtocol() otlpProtocol {
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"); p != "" {
+		return otlpProtocol(p)
+	}
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); p != "" {
+		return otlpProtocol(p)
+	}
+	return defaultOTLPProtocol
+}
+
+func newHTTPMetricExporter(ctx context.Context, ep otlpEndpoint, tlsConfig *tls.Config) (sdkmetric.Exporter, error) {
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(ep.host),
+		otlpmetrichttp.WithURLPath(ep.path),
+	}
+
+	if ep.insecure {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	} else if tlsConfig != nil {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsConfig))
+	}
+
+	if isCompressionEnabled() {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+
+	return otlpmetrichttp.New(ctx, opts...)
+}
+
+func newGRPCMetricExporter(ctx context.Context, ep otlpEndpoint, tlsConfig *tls.Config) (sdkmetric.Exporter, error) {
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(ep.host),
+	}
+
+	if ep.insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	} else if tlsConfig != nil {
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	if isCompressionEnabled() {
+		opts = append(opts, otlpmetricgrpc.WithCompressor(gzip.Name))
+	}
+
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
+// newSampler builds the sampler chosen via OTEL_TRACES_SAMPLER and
+// OTEL_TRACES_SAMPLER_ARG. Only the "parentbased_*" values get wrapped in
+// ParentBased; the bare values (always_on, always_off, jaeger_remote, ...)
+// are returned as-is so they keep their spec-defined meaning regardless of
+// any remote parent's sampling decision.
+func newSampler(serviceName string) (sdktrace.Sampler, error) {
+	kind := os.Getenv("OTEL_TRACES_SAMPLER")
+	if kind == "" {
+		kind = "parentbased_always_on"
+	}
+	arg := os.Getenv("OTEL_TRACES_SAMPLER_ARG")
+
+	parentBased := strings.HasPrefix(kind, "parentbased_")
+	root, err := newRootSampler(strings.TrimPrefix(kind, "parentbased_"), arg, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentBased {
+		return sdktrace.ParentBased(root), nil
+	}
+	return root, nil
+}
+
+func newRootSampler(kind, arg, serviceName string) (sdktrace.Sampler, error) {
+	switch kind {
+	case "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "traceidratio":
+		ratio, err := parseSamplerRatio(arg)
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.TraceIDRatioBased(ratio), nil
+	case "jaeger_remote":
+		return newJaegerRemoteSampler(serviceName, arg), nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_SAMPLER %q", kind)
+	}
+}
+
+func parseSamplerRatio(arg string) (float64, error) {
+	if arg == "" {
+		return 1.0, nil
+	}
+	ratio, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing OTEL_TRACES_SAMPLER_ARG %q: %w", arg, err)
+	}
+	return ratio, nil
+}
+
+// jaegerRemoteArg is the parsed form of OTEL_TRACES_SAMPLER_ARG for the
+// jaeger_remote sampler, e.g.
+// "endpoint=http://jaeger-collector:5778/sampling,pollingIntervalMs=5000,initialSamplingRate=0.25".
+type jaegerRemoteArg struct {
+	endpoint            string
+	pollingInterval     time.Duration
+	initialSamplingRate float64
+}
+
+func parseJaegerRemoteArg(arg string) jaegerRemoteArg {
+	cfg := jaegerRemoteArg{
+		endpoint:            "http://jaeger-collector:5778/sampling",
+		pollingInterval:     time.Minute,
+		initialSamplingRate: 1.0,
+	}
+
+	for _, pair := range strings.Split(arg, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "endpoint":
+			cfg.endpoint = kv[1]
+		case "pollingIntervalMs":
+			if ms, err := strconv.Atoi(kv[1]); err == nil {
+				cfg.pollingInterval = time.Duration(ms) * time.Millisecond
+			}
+		case "initialSamplingRate":
+			if rate, err := strconv.ParseFloat(kv[1], 64); err == nil {
+				cfg.initialSamplingRate = rate
+			}
+		}
+	}
+
+	return cfg
+}
+
+// newJaegerRemoteSampler polls a Jaeger remote sampling endpoint for
+// per-service sampling strategies, using serviceName as the operation key.
+func newJaegerRemoteSampler(serviceName, arg string) sdktrace.Sampler {
+	cfg := parseJaegerRemoteArg(arg)
+
+	return jaegerremote.New(
+		serviceName,
+		jaegerremote.WithSamplingServerURL(cfg.endpoint),
+		jaegerremote.WithSamplingRefreshInterval(cfg.pollingInterval),
+		jaegerremote.WithInitialSampler(sdktrace.TraceIDRatioBased(cfg.initialSamplingRate)),
 	)
+}
 
-	// Set global trace provider and propagator
-	otel.SetTracerProvider(tracerProvider)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	))
+// resolveInsecure applies OTEL_EXPORTER_OTLP_INSECURE on top of a scheme-derived
+// default, so an explicit env var always wins over what the endpoint URL implies.
+func resolveInsecure(defaultInsecure bool) bool {
+	v := os.Getenv("OTEL_EXPORTER_OTLP_INSECURE")
+	if v == "" {
+		return defaultInsecure
+	}
+	v = strings.ToLower(v)
+	return v == "true" || v == "1"
+}
 
-	// Return shutdown function
-	return tracerProvider.Shutdown, nil
+func isCompressionEnabled() bool {
+	return strings.EqualFold(os.Getenv("OTEL_EXPORTER_OTLP_COMPRESSION"), "gzip")
+}
+
+// loadTLSConfig builds a tls.Config trusting the CA certificate pointed to by
+// OTEL_EXPORTER_OTLP_CERTIFICATE, if set. It returns a nil config with no
+// error when the env var is unset, so callers fall back to system defaults.
+func loadTLSConfig() (*tls.Config, error) {
+	certFile := os.Getenv("OTEL_EXPORTER_OTLP_CERTIFICATE")
+	if certFile == "" {
+		return nil, nil
+	}
+
+	pemBytes, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading OTLP certificate %q: %w", certFile, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemBytes) {
+		return nil, fmt.Errorf("parsing OTLP certificate %q", certFile)
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
 }
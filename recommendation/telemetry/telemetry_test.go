@@ -0,0 +1,94 @@
+package telemetry
+
+import "testing"
+
+func TestResolveTracesEndpoint(t *testing.T) {
+	tests := []struct {
+		name           string
+		baseEndpoint   string
+		tracesEndpoint string
+		insecureEnv    string
+		wantHost       string
+		wantPath       string
+		wantInsecure   bool
+	}{
+		{
+			name:         "defaults when nothing is set",
+			wantHost:     "otel-collector:4318",
+			wantPath:     "/v1/traces",
+			wantInsecure: true,
+		},
+		{
+			name:         "base endpoint gets /v1/traces appended",
+			baseEndpoint: "http://collector:4318",
+			wantHost:     "collector:4318",
+			wantPath:     "/v1/traces",
+			wantInsecure: true,
+		},
+		{
+			name:         "base endpoint path is kept with /v1/traces appended",
+			baseEndpoint: "http://collector:4318/custom",
+			wantHost:     "collector:4318",
+			wantPath:     "/custom/v1/traces",
+			wantInsecure: true,
+		},
+		{
+			name:         "https base endpoint toggles insecure off",
+			baseEndpoint: "https://collector:4318",
+			wantHost:     "collector:4318",
+			wantPath:     "/v1/traces",
+			wantInsecure: false,
+		},
+		{
+			name:           "traces endpoint is used verbatim, path included",
+			tracesEndpoint: "http://collector:4318/custom/traces",
+			wantHost:       "collector:4318",
+			wantPath:       "/custom/traces",
+			wantInsecure:   true,
+		},
+		{
+			name:           "traces endpoint wins over base endpoint",
+			baseEndpoint:   "http://base:4318",
+			tracesEndpoint: "https://traces-only:4318/v1/traces",
+			wantHost:       "traces-only:4318",
+			wantPath:       "/v1/traces",
+			wantInsecure:   false,
+		},
+		{
+			name:         "explicit insecure=true overrides https scheme",
+			baseEndpoint: "https://collector:4318",
+			insecureEnv:  "true",
+			wantHost:     "collector:4318",
+			wantPath:     "/v1/traces",
+			wantInsecure: true,
+		},
+		{
+			name:         "explicit insecure=false overrides http scheme",
+			baseEndpoint: "http://collector:4318",
+			insecureEnv:  "false",
+			wantHost:     "collector:4318",
+			wantPath:     "/v1/traces",
+			wantInsecure: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", tt.baseEndpoint)
+			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", tt.tracesEndpoint)
+			t.Setenv("OTEL_EXPORTER_OTLP_INSECURE", tt.insecureEnv)
+
+			got := resolveTracesEndpoint()
+
+			if got.host != tt.wantHost {
+				t.Errorf("host = %q, want %q", got.host, tt.wantHost)
+			}
+			if got.path != tt.wantPath {
+				t.Errorf("path = %q, want %q", got.path, tt.wantPath)
+			}
+			if got.insecure != tt.wantInsecure {
+				t.Errorf("insecure = %v, want %v", got.insecure, tt.wantInsecure)
+			}
+		})
+	}
+}